@@ -0,0 +1,81 @@
+// Package cache implements a small on-disk corpus of previously fetched
+// GitHub metrics, keyed by actor. fetchAllStats still lists every repo
+// on every run (the repositories connection has no "updated since" filter
+// to page against safely), but it uses this snapshot to skip the
+// per-repo linguist tree walk for repos whose UpdatedAt hasn't moved
+// since LastRun, since that REST call — not the repo listing — is the
+// expensive part. An earlier version tried to short-circuit the listing
+// itself by breaking out of pagination on the first unchanged repo; that
+// silently dropped repos that had just become eligible (e.g. un-excluded
+// or un-forked) but still carried an old UpdatedAt and no cache entry.
+// That short-circuit no longer exists.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RepoSnapshot is the last known state of a single repository.
+type RepoSnapshot struct {
+	UpdatedAt time.Time      `json:"updated_at"`
+	Languages map[string]int `json:"languages"`
+	Stars     int            `json:"stars"`
+	Forks     int            `json:"forks"`
+}
+
+// Snapshot is the on-disk corpus for a single GitHub actor.
+type Snapshot struct {
+	Actor   string                  `json:"actor"`
+	LastRun time.Time               `json:"last_run"`
+	Repos   map[string]RepoSnapshot `json:"repos"`
+}
+
+// Dir returns the directory snapshots are stored in, honoring
+// GITHUB_METRICS_CACHE_DIR when set.
+func Dir() string {
+	if d := os.Getenv("GITHUB_METRICS_CACHE_DIR"); d != "" {
+		return d
+	}
+	return ".github-metrics-cache"
+}
+
+func path(actor string) string {
+	return filepath.Join(Dir(), actor+".json")
+}
+
+// Load reads the snapshot for actor from disk. A missing file is not an
+// error; it yields an empty snapshot so the first run fetches everything.
+func Load(actor string) (*Snapshot, error) {
+	data, err := os.ReadFile(path(actor))
+	if os.IsNotExist(err) {
+		return &Snapshot{Actor: actor, Repos: map[string]RepoSnapshot{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	if snap.Repos == nil {
+		snap.Repos = map[string]RepoSnapshot{}
+	}
+	return &snap, nil
+}
+
+// Save writes the snapshot for actor to disk, creating the cache
+// directory if it doesn't already exist.
+func Save(snap *Snapshot) error {
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path(snap.Actor), data, 0644)
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	t.Setenv("GITHUB_METRICS_CACHE_DIR", t.TempDir())
+
+	snap := &Snapshot{
+		Actor:   "octocat",
+		LastRun: time.Now().Truncate(time.Second),
+		Repos: map[string]RepoSnapshot{
+			"github/hello-world": {
+				UpdatedAt: time.Now().Truncate(time.Second),
+				Languages: map[string]int{"Go": 1234},
+				Stars:     5,
+				Forks:     2,
+			},
+		},
+	}
+
+	if err := Save(snap); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := Load("octocat")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got.Actor != snap.Actor {
+		t.Errorf("Actor = %q, want %q", got.Actor, snap.Actor)
+	}
+	if !got.LastRun.Equal(snap.LastRun) {
+		t.Errorf("LastRun = %v, want %v", got.LastRun, snap.LastRun)
+	}
+	repo, ok := got.Repos["github/hello-world"]
+	if !ok {
+		t.Fatal("expected github/hello-world in loaded snapshot")
+	}
+	if repo.Stars != 5 || repo.Forks != 2 || repo.Languages["Go"] != 1234 {
+		t.Errorf("repo = %+v, want Stars=5 Forks=2 Languages[Go]=1234", repo)
+	}
+}
+
+func TestLoadMissingFileYieldsEmptySnapshot(t *testing.T) {
+	t.Setenv("GITHUB_METRICS_CACHE_DIR", t.TempDir())
+
+	snap, err := Load("nobody")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if snap.Actor != "nobody" || len(snap.Repos) != 0 {
+		t.Errorf("snap = %+v, want empty snapshot for nobody", snap)
+	}
+}
@@ -0,0 +1,245 @@
+// Package commits computes true authored lines-added/deleted and commit
+// counts for a GitHub user by walking each repo's default branch commit
+// history, rather than approximating "lines changed" from language byte
+// sizes the way the overview used to.
+package commits
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// Stats is the aggregate result of walking every repo's commit history.
+type Stats struct {
+	Commits       int
+	LinesAdded    int
+	LinesDeleted  int
+	LongestStreak int          // longest run of consecutive days with at least one authored commit
+	WeeklyCommits []WeekBucket // commit counts per calendar week, chronological, for trend rendering
+}
+
+// WeekBucket is the commit count for a single calendar week, identified
+// by the date (Mon, "2006-01-02") of that week's first day.
+type WeekBucket struct {
+	Week    string
+	Commits int
+}
+
+// Config controls how the walker talks to the GitHub API.
+type Config struct {
+	// Concurrency is the number of repos walked in parallel. Defaults to 4.
+	Concurrency int
+}
+
+type viewerQuery struct {
+	User struct {
+		ID githubv4.ID
+	} `graphql:"user(login: $login)"`
+}
+
+type historyQuery struct {
+	Repository struct {
+		DefaultBranchRef struct {
+			Target struct {
+				Commit struct {
+					History struct {
+						PageInfo struct {
+							EndCursor   githubv4.String
+							HasNextPage githubv4.Boolean
+						}
+						Nodes []struct {
+							Additions     githubv4.Int
+							Deletions     githubv4.Int
+							CommittedDate githubv4.DateTime
+						}
+					} `graphql:"history(first: 100, after: $cursor, author: {id: $authorID})"`
+				} `graphql:"... on Commit"`
+			} `graphql:"target"`
+		} `graphql:"defaultBranchRef"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// Walk pages through the commit history of each named repo (owned by
+// login) authored by login, aggregating commit counts, line changes and
+// the longest streak of consecutive days with a commit. Up to
+// cfg.Concurrency repos are walked at once.
+func Walk(ctx context.Context, client *githubv4.Client, login string, repoNames []string, cfg Config) (Stats, error) {
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var viewer viewerQuery
+	if err := client.Query(ctx, &viewer, map[string]interface{}{"login": githubv4.String(login)}); err != nil {
+		return Stats{}, fmt.Errorf("resolve author id for %s: %w", login, err)
+	}
+
+	var (
+		mu       sync.Mutex
+		stats    Stats
+		days     = map[string]bool{}
+		weeks    = map[string]int{}
+		sem      = make(chan struct{}, concurrency)
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	for _, name := range repoNames {
+		name := name
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			added, deleted, commitCount, commitDays, err := walkRepo(ctx, client, login, name, viewer.User.ID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("walk %s/%s: %w", login, name, err)
+				}
+				return
+			}
+			stats.LinesAdded += added
+			stats.LinesDeleted += deleted
+			stats.Commits += commitCount
+			for _, d := range commitDays {
+				days[d] = true
+				weeks[weekStart(d)]++
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return stats, firstErr
+	}
+
+	stats.LongestStreak = longestStreak(days)
+	stats.WeeklyCommits = sortedWeekBuckets(weeks)
+	return stats, nil
+}
+
+// walkRepo pages through a single repo's default branch history.
+func walkRepo(ctx context.Context, client *githubv4.Client, owner, name string, authorID githubv4.ID) (added, deleted, commitCount int, commitDays []string, err error) {
+	var cursor *githubv4.String
+
+	for {
+		var query historyQuery
+		vars := map[string]interface{}{
+			"owner":    githubv4.String(owner),
+			"name":     githubv4.String(name),
+			"cursor":   cursor,
+			"authorID": authorID,
+		}
+
+		if qerr := queryWithBackoff(ctx, client, &query, vars); qerr != nil {
+			return added, deleted, commitCount, commitDays, qerr
+		}
+
+		history := query.Repository.DefaultBranchRef.Target.Commit.History
+		for _, node := range history.Nodes {
+			added += int(node.Additions)
+			deleted += int(node.Deletions)
+			commitCount++
+			commitDays = append(commitDays, node.CommittedDate.Format("2006-01-02"))
+		}
+
+		if !bool(history.PageInfo.HasNextPage) {
+			break
+		}
+		cursor = &history.PageInfo.EndCursor
+	}
+
+	return added, deleted, commitCount, commitDays, nil
+}
+
+// queryWithBackoff retries on GitHub's secondary rate limit (HTTP 403
+// "abuse detection") responses with exponential backoff, up to 5 tries.
+func queryWithBackoff(ctx context.Context, client *githubv4.Client, query interface{}, vars map[string]interface{}) error {
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		err := client.Query(ctx, query, vars)
+		if err == nil {
+			return nil
+		}
+		if attempt >= 4 || !isAbuseRateLimit(err) {
+			return err
+		}
+		log.Printf("⏳ Secondary rate limit hit, backing off %s (attempt %d/5)", backoff, attempt+1)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isAbuseRateLimit(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "403") || strings.Contains(msg, "abuse detection")
+}
+
+// weekStart returns the Monday (formatted "2006-01-02") of the calendar
+// week containing day (also "2006-01-02"), so same-week commit days
+// bucket together regardless of which weekday they fall on.
+func weekStart(day string) string {
+	t, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		return day
+	}
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return t.AddDate(0, 0, -offset).Format("2006-01-02")
+}
+
+// sortedWeekBuckets turns a week->count map into a chronologically
+// sorted slice, since callers render WeeklyCommits as a time series.
+func sortedWeekBuckets(counts map[string]int) []WeekBucket {
+	buckets := make([]WeekBucket, 0, len(counts))
+	for week, n := range counts {
+		buckets = append(buckets, WeekBucket{Week: week, Commits: n})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Week < buckets[j].Week })
+	return buckets
+}
+
+// longestStreak returns the length of the longest run of consecutive
+// calendar days present in days (formatted "2006-01-02").
+func longestStreak(days map[string]bool) int {
+	if len(days) == 0 {
+		return 0
+	}
+
+	parsed := make([]time.Time, 0, len(days))
+	for d := range days {
+		t, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			continue
+		}
+		parsed = append(parsed, t)
+	}
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i].Before(parsed[j]) })
+
+	longest, current := 1, 1
+	for i := 1; i < len(parsed); i++ {
+		if parsed[i].Sub(parsed[i-1]) == 24*time.Hour {
+			current++
+		} else {
+			current = 1
+		}
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
@@ -0,0 +1,63 @@
+package commits
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLongestStreak(t *testing.T) {
+	cases := []struct {
+		name string
+		days map[string]bool
+		want int
+	}{
+		{"empty", map[string]bool{}, 0},
+		{"single day", map[string]bool{"2024-01-01": true}, 1},
+		{"three in a row", map[string]bool{
+			"2024-01-01": true, "2024-01-02": true, "2024-01-03": true,
+		}, 3},
+		{"gap breaks the streak", map[string]bool{
+			"2024-01-01": true, "2024-01-02": true, "2024-01-05": true,
+		}, 2},
+		{"longest run wins over a later shorter one", map[string]bool{
+			"2024-01-01": true, "2024-01-02": true, "2024-01-03": true,
+			"2024-02-01": true, "2024-02-02": true,
+		}, 3},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := longestStreak(c.days); got != c.want {
+				t.Errorf("longestStreak(%v) = %d, want %d", c.days, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	cases := map[string]string{
+		"2024-01-01": "2024-01-01", // a Monday
+		"2024-01-03": "2024-01-01", // Wednesday of the same week
+		"2024-01-07": "2024-01-01", // Sunday of the same week
+		"2024-01-08": "2024-01-08", // the following Monday
+	}
+	for day, want := range cases {
+		if got := weekStart(day); got != want {
+			t.Errorf("weekStart(%q) = %q, want %q", day, got, want)
+		}
+	}
+}
+
+func TestSortedWeekBuckets(t *testing.T) {
+	counts := map[string]int{
+		"2024-01-08": 2,
+		"2024-01-01": 3,
+	}
+	want := []WeekBucket{
+		{Week: "2024-01-01", Commits: 3},
+		{Week: "2024-01-08", Commits: 2},
+	}
+	if got := sortedWeekBuckets(counts); !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedWeekBuckets(%v) = %+v, want %+v", counts, got, want)
+	}
+}
@@ -0,0 +1,134 @@
+// Package filter implements a composable repository filter pipeline.
+// Named predicates (fork, archived, min-stars:5, ...) are combined from
+// a single spec string, e.g. REPO_FILTERS="!fork,!archived,min-stars:1",
+// so new predicates can be added without touching the caller.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Repo is the subset of repository fields predicates need in order to
+// decide whether a repository should be kept.
+type Repo struct {
+	Name       string
+	Owner      string
+	IsFork     bool
+	IsArchived bool
+	IsPrivate  bool
+	Stars      int
+	Topics     []string
+}
+
+// Predicate reports whether a repo should be kept.
+type Predicate func(Repo) bool
+
+// Factory builds a Predicate from the argument following a predicate's
+// name in a filter spec, e.g. "min-stars:5" calls the "min-stars"
+// factory with arg "5".
+type Factory func(arg string) (Predicate, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a named predicate factory so it can be referenced from
+// a filter spec. Intended to be called from an init() alongside the
+// predicate's implementation, so new predicates need no changes here.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+func init() {
+	Register("fork", func(string) (Predicate, error) {
+		return func(r Repo) bool { return r.IsFork }, nil
+	})
+	Register("archived", func(string) (Predicate, error) {
+		return func(r Repo) bool { return r.IsArchived }, nil
+	})
+	Register("private", func(string) (Predicate, error) {
+		return func(r Repo) bool { return r.IsPrivate }, nil
+	})
+	Register("min-stars", func(arg string) (Predicate, error) {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("min-stars: %w", err)
+		}
+		return func(r Repo) bool { return r.Stars >= n }, nil
+	})
+	Register("topic", func(arg string) (Predicate, error) {
+		if arg == "" {
+			return nil, fmt.Errorf("topic: missing topic name")
+		}
+		return func(r Repo) bool {
+			for _, t := range r.Topics {
+				if t == arg {
+					return true
+				}
+			}
+			return false
+		}, nil
+	})
+	Register("name", func(arg string) (Predicate, error) {
+		if arg == "" {
+			return nil, fmt.Errorf("name: missing repo name")
+		}
+		return func(r Repo) bool { return r.Name == arg }, nil
+	})
+	Register("name-regex", func(arg string) (Predicate, error) {
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("name-regex: %w", err)
+		}
+		return func(r Repo) bool { return re.MatchString(r.Name) }, nil
+	})
+}
+
+// Parse builds a composite Predicate from a comma-separated spec such
+// as "!fork,!archived,min-stars:1". Every term must be satisfied for
+// the resulting Predicate to report true (logical AND); a term
+// prefixed with "!" is negated. An empty spec keeps everything.
+func Parse(spec string) (Predicate, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return func(Repo) bool { return true }, nil
+	}
+
+	var preds []Predicate
+	for _, term := range strings.Split(spec, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		negate := strings.HasPrefix(term, "!")
+		if negate {
+			term = term[1:]
+		}
+
+		name, arg, _ := strings.Cut(term, ":")
+		factory, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("filter: unknown predicate %q", name)
+		}
+		pred, err := factory(arg)
+		if err != nil {
+			return nil, fmt.Errorf("filter: %s: %w", term, err)
+		}
+		if negate {
+			inner := pred
+			pred = func(r Repo) bool { return !inner(r) }
+		}
+		preds = append(preds, pred)
+	}
+
+	return func(r Repo) bool {
+		for _, p := range preds {
+			if !p(r) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
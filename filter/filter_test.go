@@ -0,0 +1,59 @@
+package filter
+
+import "testing"
+
+func TestParseEmptySpecKeepsEverything(t *testing.T) {
+	pred, err := Parse("")
+	if err != nil {
+		t.Fatalf("Parse(\"\"): %v", err)
+	}
+	if !pred(Repo{}) {
+		t.Error("empty spec should keep every repo")
+	}
+}
+
+func TestParsePredicates(t *testing.T) {
+	cases := []struct {
+		spec string
+		repo Repo
+		want bool
+	}{
+		{"fork", Repo{IsFork: true}, true},
+		{"fork", Repo{IsFork: false}, false},
+		{"!fork", Repo{IsFork: false}, true},
+		{"archived", Repo{IsArchived: true}, true},
+		{"!archived", Repo{IsArchived: true}, false},
+		{"private", Repo{IsPrivate: true}, true},
+		{"min-stars:5", Repo{Stars: 5}, true},
+		{"min-stars:5", Repo{Stars: 4}, false},
+		{"topic:go", Repo{Topics: []string{"go", "cli"}}, true},
+		{"topic:go", Repo{Topics: []string{"cli"}}, false},
+		{"name:foo", Repo{Name: "foo"}, true},
+		{"name-regex:^foo", Repo{Name: "foobar"}, true},
+		{"name-regex:^foo", Repo{Name: "barfoo"}, false},
+		{"!fork,min-stars:1", Repo{IsFork: false, Stars: 2}, true},
+		{"!fork,min-stars:1", Repo{IsFork: true, Stars: 2}, false},
+	}
+
+	for _, c := range cases {
+		pred, err := Parse(c.spec)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.spec, err)
+		}
+		if got := pred(c.repo); got != c.want {
+			t.Errorf("Parse(%q)(%+v) = %v, want %v", c.spec, c.repo, got, c.want)
+		}
+	}
+}
+
+func TestParseUnknownPredicate(t *testing.T) {
+	if _, err := Parse("nonsense"); err == nil {
+		t.Error("expected an error for an unknown predicate")
+	}
+}
+
+func TestParseMinStarsRequiresNumber(t *testing.T) {
+	if _, err := Parse("min-stars:abc"); err == nil {
+		t.Error("expected an error for a non-numeric min-stars argument")
+	}
+}
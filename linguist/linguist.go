@@ -0,0 +1,185 @@
+// Package linguist applies GitHub Linguist-style rules to tell
+// vendored, generated, and documentation files apart from hand-written
+// source, so their bytes can be excluded from per-language totals
+// instead of inflating them the way raw tree sizes do.
+package linguist
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// DefaultVendoredPatterns mirrors a subset of github-linguist's
+// vendor.yml: paths that are near-universally vendored dependencies.
+var DefaultVendoredPatterns = []string{
+	"vendor/**",
+	"node_modules/**",
+	"Godeps/**",
+	"third_party/**",
+	"**/*.min.js",
+	"**/*.min.css",
+	"**/dist/**",
+	"**/vendor/**",
+}
+
+// DefaultGeneratedPatterns mirrors a subset of generated.yml: files
+// whose content is produced by a tool and shouldn't count as
+// hand-written code.
+var DefaultGeneratedPatterns = []string{
+	"**/*_generated.go",
+	"**/*.pb.go",
+	"**/*_pb2.py",
+	"**/*.g.dart",
+	"**/swagger.json",
+	"**/*.lock",
+}
+
+// ExtensionLanguages maps common file extensions to the language name
+// used elsewhere in the tool (e.g. in render.Palette), for attributing
+// tree-entry bytes to a language when no richer signal is available.
+var ExtensionLanguages = map[string]string{
+	".go": "Go", ".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript",
+	".ts": "TypeScript", ".tsx": "TypeScript", ".py": "Python",
+	".java": "Java", ".rb": "Ruby", ".php": "PHP", ".rs": "Rust",
+	".c": "C", ".h": "C", ".cpp": "C++", ".cs": "C#", ".swift": "Swift",
+	".kt": "Kotlin", ".sh": "Shell", ".html": "HTML", ".css": "CSS",
+	".scss": "SCSS", ".vue": "Vue", ".r": "R", ".scala": "Scala",
+	".hs": "Haskell", ".ex": "Elixir", ".exs": "Elixir", ".lua": "Lua",
+	".pl": "Perl", ".m": "Objective-C", ".asm": "Assembly",
+	".ps1": "PowerShell", ".dart": "Dart", ".groovy": "Groovy",
+	".md": "Markdown", ".json": "JSON", ".yml": "YAML", ".yaml": "YAML",
+}
+
+// Config toggles which rule sets apply and carries any per-repo
+// .gitattributes overrides.
+type Config struct {
+	ExcludeVendored  bool
+	ExcludeGenerated bool
+	Overrides        Overrides
+}
+
+// Overrides holds per-path linguist-vendored/linguist-generated
+// attribute values parsed from a repo's .gitattributes.
+type Overrides struct {
+	Vendored  map[string]bool
+	Generated map[string]bool
+}
+
+// ParseGitattributes extracts linguist-vendored and linguist-generated
+// overrides from the contents of a .gitattributes file.
+func ParseGitattributes(data []byte) Overrides {
+	ov := Overrides{Vendored: map[string]bool{}, Generated: map[string]bool{}}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		pattern := fields[0]
+		for _, attr := range fields[1:] {
+			switch attr {
+			case "linguist-vendored", "linguist-vendored=true":
+				ov.Vendored[pattern] = true
+			case "-linguist-vendored", "linguist-vendored=false":
+				ov.Vendored[pattern] = false
+			case "linguist-generated", "linguist-generated=true":
+				ov.Generated[pattern] = true
+			case "-linguist-generated", "linguist-generated=false":
+				ov.Generated[pattern] = false
+			}
+		}
+	}
+	return ov
+}
+
+// Entry is a single blob in a repo's tree.
+type Entry struct {
+	Path     string
+	Size     int
+	Language string
+}
+
+// IsVendored reports whether path matches a vendored rule, honoring
+// any .gitattributes override.
+func (cfg Config) IsVendored(p string) bool {
+	if v, ok := matchOverride(cfg.Overrides.Vendored, p); ok {
+		return v
+	}
+	return matchAny(DefaultVendoredPatterns, p)
+}
+
+// IsGenerated reports whether path matches a generated rule, honoring
+// any .gitattributes override.
+func (cfg Config) IsGenerated(p string) bool {
+	if v, ok := matchOverride(cfg.Overrides.Generated, p); ok {
+		return v
+	}
+	return matchAny(DefaultGeneratedPatterns, p)
+}
+
+// ExcludedBytes returns, per language, the number of bytes in entries
+// that should be subtracted from that language's totals because they
+// are vendored and/or generated (per cfg's toggles).
+func (cfg Config) ExcludedBytes(entries []Entry) map[string]int {
+	excluded := make(map[string]int)
+	for _, e := range entries {
+		if e.Language == "" {
+			continue
+		}
+		if (cfg.ExcludeVendored && cfg.IsVendored(e.Path)) || (cfg.ExcludeGenerated && cfg.IsGenerated(e.Path)) {
+			excluded[e.Language] += e.Size
+		}
+	}
+	return excluded
+}
+
+func matchOverride(overrides map[string]bool, p string) (bool, bool) {
+	for pattern, value := range overrides {
+		if globMatch(pattern, p) {
+			return value, true
+		}
+	}
+	return false, false
+}
+
+func matchAny(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if globMatch(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch reports whether p matches a gitignore-style glob pattern,
+// supporting "**" as a path-spanning wildcard and "*" within a segment.
+func globMatch(pattern, p string) bool {
+	return globToRegexp(pattern).MatchString(p)
+}
+
+func globToRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+			i++
+		default:
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			i++
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
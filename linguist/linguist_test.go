@@ -0,0 +1,61 @@
+package linguist
+
+import "testing"
+
+func TestGlobMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"vendor/**", "vendor/foo/bar.go", true},
+		{"vendor/**", "internal/vendor/bar.go", false},
+		{"**/vendor/**", "internal/vendor/bar.go", true},
+		{"**/*.min.js", "dist/app.min.js", true},
+		{"**/*.min.js", "dist/app.js", false},
+		{"**/dist/**", "web/dist/bundle.js", true},
+	}
+
+	for _, c := range cases {
+		if got := globMatch(c.pattern, c.path); got != c.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", c.pattern, c.path, got, c.want)
+		}
+	}
+}
+
+func TestParseGitattributes(t *testing.T) {
+	data := []byte(`
+# comment
+vendor/** linguist-vendored
+generated/*.go linguist-generated=true
+docs/** -linguist-vendored
+`)
+
+	ov := ParseGitattributes(data)
+
+	if !ov.Vendored["vendor/**"] {
+		t.Error("expected vendor/** to be marked vendored")
+	}
+	if ov.Vendored["docs/**"] {
+		t.Error("expected docs/** to be marked not-vendored")
+	}
+	if !ov.Generated["generated/*.go"] {
+		t.Error("expected generated/*.go to be marked generated")
+	}
+}
+
+func TestExcludedBytesSkipsEmptyLanguage(t *testing.T) {
+	cfg := Config{ExcludeVendored: true}
+	entries := []Entry{
+		{Path: "vendor/foo.go", Size: 100, Language: "Go"},
+		{Path: "vendor/unknown.xyz", Size: 50, Language: ""},
+	}
+
+	excluded := cfg.ExcludedBytes(entries)
+	if excluded["Go"] != 100 {
+		t.Errorf("excluded[Go] = %d, want 100", excluded["Go"])
+	}
+	if _, ok := excluded[""]; ok {
+		t.Error("empty-language entries should never be counted")
+	}
+}
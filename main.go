@@ -1,19 +1,33 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/iliadmitriev/github-metrics/cache"
+	"github.com/iliadmitriev/github-metrics/commits"
+	"github.com/iliadmitriev/github-metrics/filter"
+	"github.com/iliadmitriev/github-metrics/linguist"
+	"github.com/iliadmitriev/github-metrics/provider"
+	ghprovider "github.com/iliadmitriev/github-metrics/provider/github"
+	"github.com/iliadmitriev/github-metrics/provider/gitea"
+	"github.com/iliadmitriev/github-metrics/provider/gitlab"
+	"github.com/iliadmitriev/github-metrics/render"
 	"github.com/joho/godotenv"
-	"github.com/shurcooL/githubv4"
-	"golang.org/x/oauth2"
 )
 
 // LanguageStat holds per-language data for languages.svg
@@ -27,103 +41,176 @@ type LanguageStat struct {
 
 // TemplateData for languages.svg
 type TemplateData struct {
+	render.Meta
 	Name      string
 	Languages []LanguageStat
 }
 
 // OverviewStats for overview.svg
 type OverviewStats struct {
+	render.Meta
 	Name          string
 	Stars         int
 	Forks         int
 	Repos         int
 	Contributions string // e.g., "1,475"
-	LinesChanged  string // "0" (not available)
+	LinesChanged  string // sum of LinesAdded+LinesDeleted, falls back to byte-size total if the commit walk fails
 	Views         string // "0" (not available)
+	LinesAdded    int
+	LinesDeleted  int
+	Commits       int
+	LongestStreak int                  // longest run of consecutive days with an authored commit
+	WeeklyCommits []commits.WeekBucket // per-week commit counts, for trend rendering/metrics
 }
 
 // Config from environment
 type Config struct {
-	GitHubActor   string
-	AccessToken   string
-	ExcludedRepos map[string]bool
-	ExcludedLangs map[string]bool
-	ExcludeForked bool
-	LangsLimit    int
+	GitHubActor        string
+	GitLabActor        string
+	GiteaActor         string
+	Providers          []provider.Provider
+	ExcludedLangs      map[string]bool
+	RepoFilter         filter.Predicate
+	LangsLimit         int
+	CommitsConcurrency int
+	ExcludeVendored    bool
+	ExcludeGenerated   bool
+	Theme              render.Theme
+	Layout             render.Layout
 }
 
-// GraphQL: Repository data
-type Repository struct {
-	Name           githubv4.String
-	IsFork         githubv4.Boolean
-	Owner          struct{ Login githubv4.String }
-	StargazerCount githubv4.Int
-	ForkCount      githubv4.Int
-	Languages      struct {
-		Edges []struct {
-			Size githubv4.Int
-			Node struct {
-				Name  githubv4.String
-				Color githubv4.String
-			}
+// githubProvider returns the configured GitHub provider, if any. The
+// commit walker and linguist tree walker are GitHub-specific features
+// that reach for its underlying API clients directly.
+func (c *Config) githubProvider() *ghprovider.Provider {
+	for _, p := range c.Providers {
+		if gh, ok := p.(*ghprovider.Provider); ok {
+			return gh
 		}
-	} `graphql:"languages(first: 20)"`
-}
-
-// GraphQL: Main repo list query
-type RepoQuery struct {
-	User struct {
-		Repositories struct {
-			PageInfo struct {
-				EndCursor   githubv4.String
-				HasNextPage githubv4.Boolean
-			}
-			Nodes []Repository
-		} `graphql:"repositories(first: 100, after: $cursor, orderBy: {field: UPDATED_AT, direction: DESC})"`
-	} `graphql:"user(login: $login)"`
+	}
+	return nil
 }
 
-// GraphQL: Contributions query (you provided this)
-type ContributionsQuery struct {
-	User struct {
-		ContributionsCollection struct {
-			ContributionCalendar struct {
-				TotalContributions githubv4.Int
-			}
+// ActorFor returns the username to query the named provider with
+// ("github", "gitlab", "gitea"), falling back to GitHubActor when no
+// forge-specific actor was configured — the common case where the same
+// username is used everywhere.
+func (c *Config) ActorFor(providerName string) string {
+	switch providerName {
+	case "gitlab":
+		if c.GitLabActor != "" {
+			return c.GitLabActor
 		}
-	} `graphql:"user(login: $login)"`
+	case "gitea":
+		if c.GiteaActor != "" {
+			return c.GiteaActor
+		}
+	}
+	return c.GitHubActor
 }
 
 func main() {
 	_ = godotenv.Load()
 
+	serve := flag.Bool("serve", getBoolEnv("SERVE", false), "run as a long-lived Prometheus exporter instead of a one-shot run")
+	flag.Parse()
+
 	cfg, err := loadConfig()
 	if err != nil {
 		log.Fatalf("❌ Config error: %v", err)
 	}
 
-	client := createClient(cfg.AccessToken)
+	if *serve {
+		addr := os.Getenv("SERVE_ADDR")
+		if addr == "" {
+			addr = ":8080"
+		}
+		interval := getDurationEnv("REFRESH_INTERVAL", 15*time.Minute)
+		if err := runServer(cfg, addr, interval); err != nil {
+			log.Fatalf("❌ Server error: %v", err)
+		}
+		return
+	}
 
-	// Fetch repo and language stats
-	langStats, overview, err := fetchAllStats(context.Background(), client, cfg)
+	overview, languageList, err := collectStats(context.Background(), cfg)
 	if err != nil {
 		log.Fatalf("❌ Failed to fetch repo stats: %v", err)
 	}
 
-	// Fetch real contributions
-	var contribQuery ContributionsQuery
-	err = client.Query(context.Background(), &contribQuery, map[string]interface{}{
-		"login": githubv4.String(cfg.GitHubActor),
-	})
+	// Render outputs
+	if err := renderLanguagesSVG(TemplateData{Meta: overview.Meta, Name: cfg.GitHubActor, Languages: languageList}); err != nil {
+		log.Fatalf("❌ Failed to render languages.svg: %v", err)
+	}
+	if err := renderOverviewSVG(overview); err != nil {
+		log.Fatalf("❌ Failed to render overview.svg: %v", err)
+	}
+
+	// Final summary message with all collected statistics (similar to Python version)
+	log.Println("\n📊 Final GitHub Statistics Summary:")
+	log.Printf("👤 User: %s", overview.Name)
+	log.Printf("⭐ Total Stars: %s", formatNumber(overview.Stars))
+	log.Printf("🍴 Total Forks: %s", formatNumber(overview.Forks))
+	log.Printf("📈 Total Contributions: %s", overview.Contributions)
+	log.Printf("💻 Total Lines Changed: %s (+%d/-%d across %d commits)", overview.LinesChanged, overview.LinesAdded, overview.LinesDeleted, overview.Commits)
+	log.Printf("🔥 Longest Streak: %d days", overview.LongestStreak)
+	log.Printf("👀 Total Repository Views: %s", overview.Views)
+	log.Printf("📦 Total Repositories: %s", formatNumber(overview.Repos))
+	log.Println("🛠️ Top Languages:")
+	for i, lang := range languageList {
+		if i >= 5 { // Show top 5 languages like in Python version
+			break
+		}
+		log.Printf("   %d. %s (%.2f%%)", i+1, lang.Name, lang.Percentage*100)
+	}
+	log.Println("✅ GitHub metrics collection completed successfully!")
+
+	log.Println("✅ Successfully generated stats/languages.svg and stats/overview.svg")
+}
+
+// collectStats runs one full scrape across every configured provider —
+// repo/language stats, the GitHub commit walk, and contribution counts
+// — and returns the data ready to render or expose as metrics. It's the
+// shared core of both the one-shot CLI run and the --serve exporter's
+// periodic refresh.
+func collectStats(ctx context.Context, cfg *Config) (OverviewStats, []LanguageStat, error) {
+	langStats, overview, repoNames, err := fetchAllStats(ctx, cfg)
 	if err != nil {
-		log.Printf("⚠️ Warning: Failed to fetch contributions: %v", err)
-		overview.Contributions = "0"
-	} else {
-		overview.Contributions = formatNumber(int(contribQuery.User.ContributionsCollection.ContributionCalendar.TotalContributions))
+		return overview, nil, err
 	}
+	overview.Meta = render.Meta{Theme: cfg.Theme, Layout: cfg.Layout}
+
+	// Walk commit history for true authored lines-changed/commit counts.
+	// This is currently GitHub-only: GitLab/Gitea commit history isn't
+	// walked yet.
+	if gh := cfg.githubProvider(); gh != nil {
+		commitStats, err := commits.Walk(ctx, gh.Client(), cfg.GitHubActor, repoNames, commits.Config{Concurrency: cfg.CommitsConcurrency})
+		if err != nil {
+			log.Printf("⚠️ Warning: Failed to walk commit history: %v", err)
+		} else {
+			overview.LinesAdded = commitStats.LinesAdded
+			overview.LinesDeleted = commitStats.LinesDeleted
+			overview.Commits = commitStats.Commits
+			overview.LongestStreak = commitStats.LongestStreak
+			overview.WeeklyCommits = commitStats.WeeklyCommits
+			overview.LinesChanged = formatNumber(commitStats.LinesAdded + commitStats.LinesDeleted)
+		}
+	}
+
+	// Fetch contributions from every provider that can report one
+	totalContributions := 0
+	for _, p := range cfg.Providers {
+		n, err := p.Contributions(ctx, cfg.ActorFor(p.Name()))
+		if err != nil {
+			log.Printf("⚠️ Warning: Failed to fetch %s contributions: %v", p.Name(), err)
+			continue
+		}
+		totalContributions += n
+	}
+	overview.Contributions = formatNumber(totalContributions)
 
 	// Set unavailable metrics to "0" (as in your example)
-	// LinesChanged is now calculated in fetchAllStats
+	// LinesChanged/LinesAdded/LinesDeleted/Commits are set from the
+	// commit walk above.
 	overview.Views = "0"
 
 	// Process languages
@@ -149,63 +236,34 @@ func main() {
 		total += kv.V
 	}
 
-	colors := []string{
-		"#f1e05a", "#3178c6", "#3e4053", "#e34c26", "#563d7c",
-		"#2b7489", "#427819", "#b07219", "#d62929", "#999999",
-	}
+	palette := render.PaletteFor(cfg.Theme)
 	languageList := make([]LanguageStat, 0, len(sorted))
 	for i, kv := range sorted {
 		pct := 0.0
 		if total > 0 {
 			pct = float64(kv.V) / float64(total)
 		}
-		color := colors[i%len(colors)]
-		if c, ok := knownLanguageColors[kv.K]; ok {
-			color = c
-		}
 		languageList = append(languageList, LanguageStat{
 			Name:       kv.K,
-			Color:      color,
+			Color:      palette.LanguageColor(kv.K, i),
 			Size:       kv.V,
 			Percentage: pct,
 			DelayMs:    i * 120,
 		})
 	}
 
-	// Render outputs
-	if err := renderLanguagesSVG(TemplateData{cfg.GitHubActor, languageList}); err != nil {
-		log.Fatalf("❌ Failed to render languages.svg: %v", err)
-	}
-	if err := renderOverviewSVG(overview); err != nil {
-		log.Fatalf("❌ Failed to render overview.svg: %v", err)
-	}
-
-	// Final summary message with all collected statistics (similar to Python version)
-	log.Println("\n📊 Final GitHub Statistics Summary:")
-	log.Printf("👤 User: %s", overview.Name)
-	log.Printf("⭐ Total Stars: %s", formatNumber(overview.Stars))
-	log.Printf("🍴 Total Forks: %s", formatNumber(overview.Forks))
-	log.Printf("📈 Total Contributions: %s", overview.Contributions)
-	log.Printf("💻 Total Lines Changed: %s", overview.LinesChanged)
-	log.Printf("👀 Total Repository Views: %s", overview.Views)
-	log.Printf("📦 Total Repositories: %s", formatNumber(overview.Repos))
-	log.Println("🛠️ Top Languages:")
-	for i, lang := range languageList {
-		if i >= 5 { // Show top 5 languages like in Python version
-			break
-		}
-		log.Printf("   %d. %s (%.2f%%)", i+1, lang.Name, lang.Percentage*100)
-	}
-	log.Println("✅ GitHub metrics collection completed successfully!")
-
-	log.Println("✅ Successfully generated stats/languages.svg and stats/overview.svg")
+	return overview, languageList, nil
 }
 
 func loadConfig() (*Config, error) {
 	actor := os.Getenv("GITHUB_ACTOR")
-	token := os.Getenv("ACCESS_TOKEN")
-	if actor == "" || token == "" {
-		return nil, fmt.Errorf("GITHUB_ACTOR and ACCESS_TOKEN must be set")
+	if actor == "" {
+		return nil, fmt.Errorf("GITHUB_ACTOR must be set")
+	}
+
+	providers, err := buildProviders(os.Getenv("PROVIDER"))
+	if err != nil {
+		return nil, fmt.Errorf("PROVIDER: %w", err)
 	}
 
 	parseList := func(s string) map[string]bool {
@@ -218,16 +276,89 @@ func loadConfig() (*Config, error) {
 		return m
 	}
 
+	repoFilter, err := buildRepoFilter(os.Getenv("EXCLUDED_REPO"), os.Getenv("REPO_FILTERS"), getBoolEnv("EXCLUDE_FORKED", true))
+	if err != nil {
+		return nil, fmt.Errorf("REPO_FILTERS: %w", err)
+	}
+
 	return &Config{
-		GitHubActor:   actor,
-		AccessToken:   token,
-		ExcludedRepos: parseList(os.Getenv("EXCLUDED_REPO")),
-		ExcludedLangs: parseList(os.Getenv("EXCLUDED_LANGS")),
-		ExcludeForked: getBoolEnv("EXCLUDE_FORKED", true),
-		LangsLimit:    getIntEnv("LANGS_LIMIT", 10),
+		GitHubActor:        actor,
+		GitLabActor:        os.Getenv("GITLAB_ACTOR"),
+		GiteaActor:         os.Getenv("GITEA_ACTOR"),
+		Providers:          providers,
+		ExcludedLangs:      parseList(os.Getenv("EXCLUDED_LANGS")),
+		RepoFilter:         repoFilter,
+		LangsLimit:         getIntEnv("LANGS_LIMIT", 10),
+		CommitsConcurrency: getIntEnv("COMMITS_CONCURRENCY", 4),
+		ExcludeVendored:    getBoolEnv("EXCLUDE_VENDORED", true),
+		ExcludeGenerated:   getBoolEnv("EXCLUDE_GENERATED", true),
+		Theme:              render.ParseTheme(os.Getenv("THEME")),
+		Layout:             render.ParseLayout(os.Getenv("LAYOUT")),
 	}, nil
 }
 
+// buildProviders instantiates one provider.Provider per name in spec
+// (a comma-separated list, e.g. "github,gitlab"), defaulting to
+// "github" alone when spec is empty, so stats can be aggregated across
+// forges in a single run.
+func buildProviders(spec string) ([]provider.Provider, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = "github"
+	}
+
+	var providers []provider.Provider
+	for _, name := range strings.Split(spec, ",") {
+		switch strings.TrimSpace(name) {
+		case "github":
+			token := os.Getenv("ACCESS_TOKEN")
+			if token == "" {
+				return nil, fmt.Errorf("ACCESS_TOKEN must be set to use the github provider")
+			}
+			providers = append(providers, ghprovider.New(token))
+		case "gitlab":
+			token := os.Getenv("GITLAB_TOKEN")
+			if token == "" {
+				return nil, fmt.Errorf("GITLAB_TOKEN must be set to use the gitlab provider")
+			}
+			providers = append(providers, gitlab.New(os.Getenv("GITLAB_URL"), token))
+		case "gitea":
+			token := os.Getenv("GITEA_TOKEN")
+			if token == "" {
+				return nil, fmt.Errorf("GITEA_TOKEN must be set to use the gitea provider")
+			}
+			providers = append(providers, gitea.New(os.Getenv("GITEA_URL"), token))
+		case "":
+			// tolerate stray commas, e.g. "github,"
+		default:
+			return nil, fmt.Errorf("unknown provider %q", name)
+		}
+	}
+
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no providers configured")
+	}
+	return providers, nil
+}
+
+// buildRepoFilter assembles the REPO_FILTERS pipeline, folding in the
+// older EXCLUDED_REPO and EXCLUDE_FORKED toggles as equivalent terms so
+// existing configs keep working unchanged.
+func buildRepoFilter(excludedRepos, repoFilters string, excludeForked bool) (filter.Predicate, error) {
+	var terms []string
+	if excludeForked {
+		terms = append(terms, "!fork")
+	}
+	for _, name := range strings.Split(excludedRepos, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			terms = append(terms, "!name:"+name)
+		}
+	}
+	if repoFilters = strings.TrimSpace(repoFilters); repoFilters != "" {
+		terms = append(terms, repoFilters)
+	}
+	return filter.Parse(strings.Join(terms, ","))
+}
+
 func getBoolEnv(key string, def bool) bool {
 	if v := os.Getenv(key); v != "" {
 		if b, err := strconv.ParseBool(v); err == nil {
@@ -246,61 +377,108 @@ func getIntEnv(key string, def int) int {
 	return def
 }
 
-func createClient(token string) *githubv4.Client {
-	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	httpClient := oauth2.NewClient(context.Background(), src)
-	return githubv4.NewClient(httpClient)
+func getDurationEnv(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return def
+}
+
+// toFilterRepo adapts a fetched provider.Repo to the shape the filter
+// pipeline operates on.
+func toFilterRepo(repo provider.Repo) filter.Repo {
+	return filter.Repo{
+		Name:       repo.Name,
+		Owner:      repo.Owner,
+		IsFork:     repo.IsFork,
+		IsArchived: repo.IsArchived,
+		IsPrivate:  repo.IsPrivate,
+		Stars:      repo.Stars,
+		Topics:     repo.Topics,
+	}
 }
 
-func fetchAllStats(ctx context.Context, client *githubv4.Client, cfg *Config) (map[string]int, OverviewStats, error) {
+// fetchAllStats lists every repo from every configured provider,
+// applies the filter pipeline and linguist exclusions, and aggregates
+// per-language byte totals plus the overview counters. It returns the
+// plain (provider-less) repo names owned by the GitHub provider, for
+// the commit walker to use.
+func fetchAllStats(ctx context.Context, cfg *Config) (map[string]int, OverviewStats, []string, error) {
 	stats := make(map[string]int)
 	overview := OverviewStats{Name: cfg.GitHubActor}
 	totalLinesChanged := 0
 
-	var cursor *githubv4.String
-	login := githubv4.String(cfg.GitHubActor)
+	snap, err := cache.Load(cfg.GitHubActor)
+	if err != nil {
+		log.Printf("⚠️ Warning: Failed to load local cache, falling back to a full fetch: %v", err)
+		snap = &cache.Snapshot{Actor: cfg.GitHubActor, Repos: map[string]cache.RepoSnapshot{}}
+	}
+	newSnap := &cache.Snapshot{Actor: cfg.GitHubActor, LastRun: time.Now(), Repos: map[string]cache.RepoSnapshot{}}
 
-	for {
-		var query RepoQuery
-		err := client.Query(ctx, &query, map[string]interface{}{
-			"login":  login,
-			"cursor": cursor,
-		})
+	var githubRepoNames []string
+
+	for _, p := range cfg.Providers {
+		actor := cfg.ActorFor(p.Name())
+		repos, err := p.ListRepos(ctx, actor)
 		if err != nil {
-			return nil, overview, err
+			return nil, overview, nil, fmt.Errorf("%s: %w", p.Name(), err)
 		}
 
-		for _, repo := range query.User.Repositories.Nodes {
-			repoName := string(repo.Name)
-			owner := string(repo.Owner.Login)
+		gh, isGitHub := p.(*ghprovider.Provider)
 
-			if owner != cfg.GitHubActor {
+		for _, repo := range repos {
+			if repo.Owner != actor {
 				continue
 			}
-			if cfg.ExcludedRepos[repoName] {
-				log.Printf("⏭️ Skipping excluded repo: %s", repoName)
+			if !cfg.RepoFilter(toFilterRepo(repo)) {
+				log.Printf("⏭️ Skipping filtered-out repo: %s/%s", p.Name(), repo.Name)
 				continue
 			}
-			if cfg.ExcludeForked && bool(repo.IsFork) {
-				log.Printf("🔀 Skipping forked repo: %s", repoName)
-				continue
+
+			cacheKey := p.Name() + "/" + repo.Name
+			repoLangs := repo.Languages
+
+			// Reuse the cached language breakdown for repos that
+			// haven't changed since the last run, instead of redoing
+			// the (possibly expensive) linguist tree walk below.
+			if cached, ok := snap.Repos[cacheKey]; ok && !snap.LastRun.IsZero() && !repo.UpdatedAt.After(snap.LastRun) {
+				log.Printf("💾 %s unchanged since last run, reusing cached languages", cacheKey)
+				repoLangs = cached.Languages
+			} else if isGitHub && (cfg.ExcludeVendored || cfg.ExcludeGenerated) {
+				repoLangs = make(map[string]int, len(repo.Languages))
+				for lang, size := range repo.Languages {
+					repoLangs[lang] = size
+				}
+				excluded, lerr := linguistExcludedBytes(ctx, gh.HTTPClient(), cfg, repo.Name, repo.DefaultBranch)
+				if lerr != nil {
+					log.Printf("⚠️ Warning: Failed to apply linguist rules for %s: %v", repo.Name, lerr)
+				} else {
+					for lang, bytes := range excluded {
+						repoLangs[lang] -= bytes
+						if repoLangs[lang] < 0 {
+							repoLangs[lang] = 0
+						}
+					}
+				}
 			}
 
-			overview.Stars += int(repo.StargazerCount)
-			overview.Forks += int(repo.ForkCount)
+			overview.Stars += repo.Stars
+			overview.Forks += repo.Forks
 			overview.Repos++
+			if isGitHub {
+				githubRepoNames = append(githubRepoNames, repo.Name)
+			}
 
 			// Collect languages and their sizes for logging (excluded languages are filtered out)
 			var languages []string
-			for _, edge := range repo.Languages.Edges {
-				lang := string(edge.Node.Name)
-				size := int(edge.Size)
-				
+			for lang, size := range repoLangs {
 				// Skip excluded languages for both stats and logging
 				if cfg.ExcludedLangs[lang] {
 					continue
 				}
-				
+
 				stats[lang] += size
 				totalLinesChanged += size
 				languages = append(languages, fmt.Sprintf("%s:%d", lang, size))
@@ -311,39 +489,167 @@ func fetchAllStats(ctx context.Context, client *githubv4.Client, cfg *Config) (m
 				languagesStr = "none"
 			}
 
-			log.Printf("✅ Processed: %s (⭐ %d, 🍴 %d, 📚 %s)", repoName, repo.StargazerCount, repo.ForkCount, languagesStr)
-		}
+			log.Printf("✅ Processed: %s (⭐ %d, 🍴 %d, 📚 %s)", cacheKey, repo.Stars, repo.Forks, languagesStr)
 
-		pageInfo := query.User.Repositories.PageInfo
-		if !pageInfo.HasNextPage {
-			break
+			newSnap.Repos[cacheKey] = cache.RepoSnapshot{
+				UpdatedAt: repo.UpdatedAt,
+				Languages: repoLangs,
+				Stars:     repo.Stars,
+				Forks:     repo.Forks,
+			}
 		}
-		cursor = &pageInfo.EndCursor
-		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err := cache.Save(newSnap); err != nil {
+		log.Printf("⚠️ Warning: Failed to persist local cache: %v", err)
 	}
 
 	// Set the total lines changed in the overview
 	overview.LinesChanged = formatNumber(totalLinesChanged)
 
-	return stats, overview, nil
+	return stats, overview, githubRepoNames, nil
+}
+
+// linguistExcludedBytes fetches a repo's full file tree (and any
+// .gitattributes overrides) over the REST API and returns the number
+// of bytes per language that should be subtracted from the GraphQL
+// language totals because they're vendored and/or generated.
+func linguistExcludedBytes(ctx context.Context, httpClient *http.Client, cfg *Config, repoName, branch string) (map[string]int, error) {
+	if branch == "" {
+		return nil, nil
+	}
+
+	overrides := linguist.Overrides{}
+	if raw, err := fetchRepoFile(ctx, httpClient, cfg.GitHubActor, repoName, ".gitattributes"); err == nil {
+		overrides = linguist.ParseGitattributes(raw)
+	}
+
+	entries, err := fetchRepoTree(ctx, httpClient, cfg.GitHubActor, repoName, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	lcfg := linguist.Config{
+		ExcludeVendored:  cfg.ExcludeVendored,
+		ExcludeGenerated: cfg.ExcludeGenerated,
+		Overrides:        overrides,
+	}
+	return lcfg.ExcludedBytes(entries), nil
+}
+
+// fetchRepoTree pages through the repo's default branch via the Git
+// Trees API (recursive=1) and guesses each blob's language from its
+// file extension.
+func fetchRepoTree(ctx context.Context, httpClient *http.Client, owner, repo, branch string) ([]linguist.Entry, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees/%s?recursive=1", owner, repo, branch)
+
+	var resp struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+			Size int    `json:"size"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := restGetJSON(ctx, httpClient, url, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Truncated {
+		log.Printf("⚠️ Warning: tree for %s/%s was truncated by the GitHub API; vendored/generated exclusions may be incomplete", owner, repo)
+	}
+
+	entries := make([]linguist.Entry, 0, len(resp.Tree))
+	for _, node := range resp.Tree {
+		if node.Type != "blob" {
+			continue
+		}
+		lang := linguist.ExtensionLanguages[extOf(node.Path)]
+		entries = append(entries, linguist.Entry{Path: node.Path, Size: node.Size, Language: lang})
+	}
+	return entries, nil
+}
+
+// fetchRepoFile fetches a single file's raw content via the Contents
+// API. A 404 is returned to the caller as an error; callers treat a
+// missing file (e.g. no .gitattributes) as "no overrides".
+func fetchRepoFile(ctx context.Context, httpClient *http.Client, owner, repo, path string) ([]byte, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", owner, repo, path)
+
+	var resp struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := restGetJSON(ctx, httpClient, url, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Encoding != "base64" {
+		return []byte(resp.Content), nil
+	}
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(resp.Content, "\n", ""))
+}
+
+// restGetJSON performs a GET against the GitHub REST API and decodes a
+// 2xx JSON response into out.
+func restGetJSON(ctx context.Context, httpClient *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GET %s: %s: %s", url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func extOf(path string) string {
+	if i := strings.LastIndexByte(path, '.'); i != -1 {
+		return path[i:]
+	}
+	return ""
 }
 
 func renderLanguagesSVG(data TemplateData) error {
-	return renderTemplate("languages.svg.tmpl", "stats/languages.svg", data)
+	return renderTemplate(render.TemplatePath(data.Theme, "languages"), "stats/languages.svg", data)
 }
 
 func renderOverviewSVG(data OverviewStats) error {
-	return renderTemplate("overview.svg.tmpl", "stats/overview.svg", data)
+	return renderTemplate(render.TemplatePath(data.Theme, "overview"), "stats/overview.svg", data)
+}
+
+// renderToBytes renders templatePath (relative to templates/, e.g.
+// "dark/languages.svg.tmpl") to an in-memory buffer instead of a file,
+// so the --serve exporter can cache and serve it without touching disk
+// on every scrape.
+func renderToBytes(templatePath string, data interface{}) ([]byte, error) {
+	tmpl, err := newSVGTemplate(templatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
-func renderTemplate(templateFile, outputFile string, data interface{}) error {
+func renderTemplate(templatePath, outputFile string, data interface{}) error {
 	if err := os.MkdirAll("stats", 0755); err != nil {
 		return err
 	}
-	tmpl := template.Must(template.New(templateFile).Funcs(template.FuncMap{
-		"mul":    func(a, b float64) float64 { return a * b },
-		"printf": fmt.Sprintf,
-	}).ParseFiles("templates/" + templateFile))
+	tmpl, err := newSVGTemplate(templatePath)
+	if err != nil {
+		return err
+	}
 
 	file, err := os.Create(outputFile)
 	if err != nil {
@@ -353,6 +659,32 @@ func renderTemplate(templateFile, outputFile string, data interface{}) error {
 	return tmpl.Execute(file, data)
 }
 
+// newSVGTemplate parses templates/<templatePath>, naming the root
+// template by its base filename (e.g. "languages.svg.tmpl") regardless
+// of which theme subdirectory it was loaded from, since that's the
+// name ParseFiles registers it under. It returns an error instead of
+// panicking so a missing or broken template for one theme degrades a
+// single render (or --serve scrape) instead of crashing the process.
+func newSVGTemplate(templatePath string) (*template.Template, error) {
+	return template.New(filepath.Base(templatePath)).Funcs(template.FuncMap{
+		"mul":       func(a, b float64) float64 { return a * b },
+		"mulInt":    func(a, b int) int { return a * b },
+		"addInt":    func(a, b int) int { return a + b },
+		"rowHeight": rowHeightFor,
+		"printf":    fmt.Sprintf,
+	}).ParseFiles("templates/" + templatePath)
+}
+
+// rowHeightFor returns the vertical spacing between language rows for
+// a layout: compact packs rows tighter than the default donut/bar
+// spacing.
+func rowHeightFor(layout render.Layout) int {
+	if layout == render.LayoutCompact {
+		return 18
+	}
+	return 26
+}
+
 // formatNumber adds commas: 1475 → "1,475"
 func formatNumber(n int) string {
 	in := strconv.Itoa(n)
@@ -369,15 +701,4 @@ func formatNumber(n int) string {
 		result.WriteRune(digit)
 	}
 	return result.String()
-}
-
-var knownLanguageColors = map[string]string{
-	"JavaScript":   "#f1e05a", "TypeScript": "#3178c6", "Python": "#3e4053", "Java": "#b07219",
-	"Go":           "#00add8", "Rust": "#dea584", "C++": "#f34b7d", "C": "#555555", "C#": "#178600",
-	"PHP":          "#4F5D95", "Ruby": "#701516", "Swift": "#ffac45", "Kotlin": "#A97BFF",
-	"Shell":        "#89e051", "HTML": "#e34c26", "CSS": "#563d7c", "SCSS": "#c6538c",
-	"Vue":          "#2c3e50", "R": "#198ce7", "Scala": "#dc322f", "Haskell": "#5e5086",
-	"Elixir":       "#6e4a7e", "Lua": "#000080", "Perl": "#0298c3", "Objective-C": "#438eff",
-	"Assembly":     "#6E4C13", "PowerShell": "#012456", "Dart": "#0175C2", "Groovy": "#e69f56",
-	"Dockerfile":   "#384d54", "Cuda": "#3A4E3A",
 }
\ No newline at end of file
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+
+	ghprovider "github.com/iliadmitriev/github-metrics/provider/github"
+	"github.com/iliadmitriev/github-metrics/provider/gitea"
+	"github.com/iliadmitriev/github-metrics/provider/gitlab"
+)
+
+func TestBuildProvidersDefaultsToGitHub(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN", "token")
+
+	providers, err := buildProviders("")
+	if err != nil {
+		t.Fatalf("buildProviders(\"\"): %v", err)
+	}
+	if len(providers) != 1 {
+		t.Fatalf("len(providers) = %d, want 1", len(providers))
+	}
+	if _, ok := providers[0].(*ghprovider.Provider); !ok {
+		t.Errorf("providers[0] = %T, want *github.Provider", providers[0])
+	}
+}
+
+func TestBuildProvidersMultiple(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN", "gh-token")
+	t.Setenv("GITLAB_TOKEN", "gl-token")
+	t.Setenv("GITEA_TOKEN", "gt-token")
+
+	providers, err := buildProviders("github, gitlab,gitea")
+	if err != nil {
+		t.Fatalf("buildProviders: %v", err)
+	}
+	if len(providers) != 3 {
+		t.Fatalf("len(providers) = %d, want 3", len(providers))
+	}
+	if _, ok := providers[1].(*gitlab.Provider); !ok {
+		t.Errorf("providers[1] = %T, want *gitlab.Provider", providers[1])
+	}
+	if _, ok := providers[2].(*gitea.Provider); !ok {
+		t.Errorf("providers[2] = %T, want *gitea.Provider", providers[2])
+	}
+}
+
+func TestBuildProvidersMissingTokenErrors(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN", "")
+	if _, err := buildProviders("github"); err == nil {
+		t.Error("expected an error when ACCESS_TOKEN is unset for the github provider")
+	}
+}
+
+func TestBuildProvidersUnknownNameErrors(t *testing.T) {
+	if _, err := buildProviders("bitbucket"); err == nil {
+		t.Error("expected an error for an unknown provider name")
+	}
+}
+
+func TestConfigActorFor(t *testing.T) {
+	cfg := &Config{GitHubActor: "ghuser", GitLabActor: "gluser"}
+
+	if got := cfg.ActorFor("github"); got != "ghuser" {
+		t.Errorf("ActorFor(github) = %q, want ghuser", got)
+	}
+	if got := cfg.ActorFor("gitlab"); got != "gluser" {
+		t.Errorf("ActorFor(gitlab) = %q, want gluser", got)
+	}
+	if got := cfg.ActorFor("gitea"); got != "ghuser" {
+		t.Errorf("ActorFor(gitea) = %q, want fallback to GitHubActor (ghuser)", got)
+	}
+}
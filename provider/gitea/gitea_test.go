@@ -0,0 +1,82 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListReposPopulatesArchivedPrivateAndTopics(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			json.NewEncoder(w).Encode([]repo{})
+			return
+		}
+		var entry repo
+		entry.Name = "hello-world"
+		entry.Owner.Login = "octocat"
+		entry.StarsCount = 3
+		entry.ForksCount = 1
+		entry.DefaultBranch = "main"
+		entry.Archived = true
+		entry.Private = true
+		entry.Topics = []string{"cli", "go"}
+		json.NewEncoder(w).Encode([]repo{entry})
+	})
+	mux.HandleFunc("/api/v1/repos/octocat/hello-world/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]int{"Go": 500})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := New(srv.URL, "token")
+	repos, err := p.ListRepos(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("ListRepos: %v", err)
+	}
+	if len(repos) != 1 {
+		t.Fatalf("len(repos) = %d, want 1", len(repos))
+	}
+
+	got := repos[0]
+	if !got.IsArchived {
+		t.Error("expected IsArchived=true")
+	}
+	if !got.IsPrivate {
+		t.Error("expected IsPrivate=true")
+	}
+	if len(got.Topics) != 2 || got.Topics[0] != "cli" {
+		t.Errorf("Topics = %v, want [cli go]", got.Topics)
+	}
+	if got.Languages["Go"] != 500 {
+		t.Errorf("Languages[Go] = %d, want 500 (real byte count, no scaling needed)", got.Languages["Go"])
+	}
+}
+
+func TestListReposSendsBearerTokenHeader(t *testing.T) {
+	var gotAuth string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Query().Get("page") != "1" {
+			json.NewEncoder(w).Encode([]repo{})
+			return
+		}
+		json.NewEncoder(w).Encode([]repo{})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := New(srv.URL, "secret-token")
+	if _, err := p.ListRepos(context.Background(), "octocat"); err != nil {
+		t.Fatalf("ListRepos: %v", err)
+	}
+	if gotAuth != "token secret-token" {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, "token secret-token")
+	}
+}
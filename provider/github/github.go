@@ -0,0 +1,156 @@
+// Package github implements provider.Provider against the GitHub
+// GraphQL and REST APIs. It's also the source of the *githubv4.Client
+// and *http.Client the commits and linguist subpackages use directly,
+// since commit-walking and tree-walking are GitHub-specific features
+// for now.
+package github
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/iliadmitriev/github-metrics/provider"
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
+)
+
+// Provider talks to a single GitHub (or GitHub Enterprise) account.
+type Provider struct {
+	client *githubv4.Client
+	http   *http.Client
+}
+
+// New creates a Provider authenticated with a personal access token.
+func New(token string) *Provider {
+	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	httpClient := oauth2.NewClient(context.Background(), src)
+	return &Provider{client: githubv4.NewClient(httpClient), http: httpClient}
+}
+
+func (p *Provider) Name() string { return "github" }
+
+// Client exposes the underlying GraphQL client for GitHub-specific
+// features (the commits walker) that don't fit the generic interface.
+func (p *Provider) Client() *githubv4.Client { return p.client }
+
+// HTTPClient exposes the underlying authenticated HTTP client for
+// GitHub-specific REST calls (the linguist tree walker).
+func (p *Provider) HTTPClient() *http.Client { return p.http }
+
+type repository struct {
+	Name             githubv4.String
+	IsFork           githubv4.Boolean
+	IsArchived       githubv4.Boolean
+	IsPrivate        githubv4.Boolean
+	Owner            struct{ Login githubv4.String }
+	StargazerCount   githubv4.Int
+	ForkCount        githubv4.Int
+	UpdatedAt        githubv4.DateTime
+	DefaultBranchRef struct {
+		Name githubv4.String
+	}
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct{ Name githubv4.String }
+		}
+	} `graphql:"repositoryTopics(first: 20)"`
+	Languages struct {
+		Edges []struct {
+			Size githubv4.Int
+			Node struct {
+				Name  githubv4.String
+				Color githubv4.String
+			}
+		}
+	} `graphql:"languages(first: 20)"`
+}
+
+type repoQuery struct {
+	User struct {
+		Repositories struct {
+			PageInfo struct {
+				EndCursor   githubv4.String
+				HasNextPage githubv4.Boolean
+			}
+			Nodes []repository
+		} `graphql:"repositories(first: 100, after: $cursor, orderBy: {field: UPDATED_AT, direction: DESC})"`
+	} `graphql:"user(login: $login)"`
+}
+
+type contributionsQuery struct {
+	User struct {
+		ContributionsCollection struct {
+			ContributionCalendar struct {
+				TotalContributions githubv4.Int
+			}
+		}
+	} `graphql:"user(login: $login)"`
+}
+
+// ListRepos pages through every repository owned by user, ordered by
+// updatedAt so callers can short-circuit on unchanged repos.
+func (p *Provider) ListRepos(ctx context.Context, user string) ([]provider.Repo, error) {
+	var (
+		repos  []provider.Repo
+		cursor *githubv4.String
+		login  = githubv4.String(user)
+	)
+
+	for {
+		var query repoQuery
+		if err := p.client.Query(ctx, &query, map[string]interface{}{"login": login, "cursor": cursor}); err != nil {
+			return nil, err
+		}
+
+		for _, node := range query.User.Repositories.Nodes {
+			repos = append(repos, toProviderRepo(node))
+		}
+
+		pageInfo := query.User.Repositories.PageInfo
+		if !bool(pageInfo.HasNextPage) {
+			break
+		}
+		cursor = &pageInfo.EndCursor
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return repos, nil
+}
+
+// toProviderRepo adapts a single GraphQL repository node to the
+// provider-agnostic shape, split out from ListRepos so the mapping can
+// be unit tested without a live GraphQL client.
+func toProviderRepo(node repository) provider.Repo {
+	languages := make(map[string]int, len(node.Languages.Edges))
+	for _, edge := range node.Languages.Edges {
+		languages[string(edge.Node.Name)] = int(edge.Size)
+	}
+	topics := make([]string, 0, len(node.RepositoryTopics.Nodes))
+	for _, t := range node.RepositoryTopics.Nodes {
+		topics = append(topics, string(t.Topic.Name))
+	}
+	return provider.Repo{
+		Name:          string(node.Name),
+		Owner:         string(node.Owner.Login),
+		IsFork:        bool(node.IsFork),
+		IsArchived:    bool(node.IsArchived),
+		IsPrivate:     bool(node.IsPrivate),
+		Topics:        topics,
+		Stars:         int(node.StargazerCount),
+		Forks:         int(node.ForkCount),
+		UpdatedAt:     node.UpdatedAt.Time,
+		DefaultBranch: string(node.DefaultBranchRef.Name),
+		Languages:     languages,
+	}
+}
+
+// Contributions returns user's total contribution count from GitHub's
+// contribution calendar.
+func (p *Provider) Contributions(ctx context.Context, user string) (int, error) {
+	var query contributionsQuery
+	if err := p.client.Query(ctx, &query, map[string]interface{}{"login": githubv4.String(user)}); err != nil {
+		return 0, err
+	}
+	return int(query.User.ContributionsCollection.ContributionCalendar.TotalContributions), nil
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+func TestToProviderRepo(t *testing.T) {
+	var node repository
+	node.Name = "hello-world"
+	node.Owner.Login = "octocat"
+	node.IsFork = true
+	node.IsArchived = true
+	node.IsPrivate = false
+	node.StargazerCount = 42
+	node.ForkCount = 7
+	node.UpdatedAt = githubv4.DateTime{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	node.DefaultBranchRef.Name = "main"
+	node.RepositoryTopics.Nodes = []struct {
+		Topic struct{ Name githubv4.String }
+	}{
+		{Topic: struct{ Name githubv4.String }{Name: "cli"}},
+		{Topic: struct{ Name githubv4.String }{Name: "golang"}},
+	}
+	node.Languages.Edges = []struct {
+		Size githubv4.Int
+		Node struct {
+			Name  githubv4.String
+			Color githubv4.String
+		}
+	}{
+		{Size: 100, Node: struct {
+			Name  githubv4.String
+			Color githubv4.String
+		}{Name: "Go"}},
+	}
+
+	repo := toProviderRepo(node)
+
+	if repo.Name != "hello-world" || repo.Owner != "octocat" {
+		t.Errorf("Name/Owner = %q/%q, want hello-world/octocat", repo.Name, repo.Owner)
+	}
+	if !repo.IsFork || !repo.IsArchived || repo.IsPrivate {
+		t.Errorf("IsFork/IsArchived/IsPrivate = %v/%v/%v, want true/true/false", repo.IsFork, repo.IsArchived, repo.IsPrivate)
+	}
+	if repo.Stars != 42 || repo.Forks != 7 {
+		t.Errorf("Stars/Forks = %d/%d, want 42/7", repo.Stars, repo.Forks)
+	}
+	if repo.DefaultBranch != "main" {
+		t.Errorf("DefaultBranch = %q, want main", repo.DefaultBranch)
+	}
+	if len(repo.Topics) != 2 || repo.Topics[0] != "cli" || repo.Topics[1] != "golang" {
+		t.Errorf("Topics = %v, want [cli golang]", repo.Topics)
+	}
+	if repo.Languages["Go"] != 100 {
+		t.Errorf("Languages[Go] = %d, want 100", repo.Languages["Go"])
+	}
+	if !repo.UpdatedAt.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("UpdatedAt = %v, want 2024-01-02", repo.UpdatedAt)
+	}
+}
+
+func TestToProviderRepoWithNoTopicsOrLanguages(t *testing.T) {
+	var node repository
+	node.Name = "empty"
+
+	repo := toProviderRepo(node)
+
+	if repo.Topics == nil {
+		t.Error("expected Topics to be an empty (non-nil) slice, not nil")
+	}
+	if len(repo.Topics) != 0 {
+		t.Errorf("Topics = %v, want empty", repo.Topics)
+	}
+	if len(repo.Languages) != 0 {
+		t.Errorf("Languages = %v, want empty", repo.Languages)
+	}
+}
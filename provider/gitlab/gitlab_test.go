@@ -0,0 +1,104 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListReposScalesLanguageBytesByRepositorySize(t *testing.T) {
+	lastActivity, err := time.Parse(time.RFC3339, "2024-01-02T00:00:00Z")
+	if err != nil {
+		t.Fatalf("time.Parse: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/users/octocat/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			json.NewEncoder(w).Encode([]project{})
+			return
+		}
+		json.NewEncoder(w).Encode([]project{
+			{
+				ID:             1,
+				Name:           "hello-world",
+				ForksCount:     2,
+				StarCount:      5,
+				LastActivityAt: lastActivity,
+				DefaultBranch:  "main",
+				Archived:       true,
+				Visibility:     "private",
+				Topics:         []string{"go", "cli"},
+				Statistics: struct {
+					RepositorySize int64 `json:"repository_size"`
+				}{RepositorySize: 1000},
+			},
+		})
+	})
+	mux.HandleFunc("/api/v4/projects/1/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]float64{"Go": 80, "Shell": 20})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := New(srv.URL, "token")
+	repos, err := p.ListRepos(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("ListRepos: %v", err)
+	}
+	if len(repos) != 1 {
+		t.Fatalf("len(repos) = %d, want 1", len(repos))
+	}
+
+	repo := repos[0]
+	if repo.Name != "hello-world" || repo.Stars != 5 || repo.Forks != 2 {
+		t.Errorf("repo = %+v, want Name=hello-world Stars=5 Forks=2", repo)
+	}
+	if !repo.IsArchived {
+		t.Error("expected IsArchived=true")
+	}
+	if !repo.IsPrivate {
+		t.Error("expected IsPrivate=true for a non-public visibility")
+	}
+	if len(repo.Topics) != 2 || repo.Topics[0] != "go" {
+		t.Errorf("Topics = %v, want [go cli]", repo.Topics)
+	}
+
+	// 80% and 20% of a 1000-byte repository, not an arbitrary constant.
+	if repo.Languages["Go"] != 800 {
+		t.Errorf("Languages[Go] = %d, want 800", repo.Languages["Go"])
+	}
+	if repo.Languages["Shell"] != 200 {
+		t.Errorf("Languages[Shell] = %d, want 200", repo.Languages["Shell"])
+	}
+}
+
+func TestIsPrivateTrueForInternalVisibility(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/users/octocat/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			json.NewEncoder(w).Encode([]project{})
+			return
+		}
+		json.NewEncoder(w).Encode([]project{{ID: 1, Name: "internal-repo", Visibility: "internal"}})
+	})
+	mux.HandleFunc("/api/v4/projects/1/languages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]float64{})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := New(srv.URL, "token")
+	repos, err := p.ListRepos(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("ListRepos: %v", err)
+	}
+	if !repos[0].IsPrivate {
+		t.Error("expected a non-public visibility to be reported as private")
+	}
+}
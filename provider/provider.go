@@ -0,0 +1,37 @@
+// Package provider defines a forge-agnostic interface for listing a
+// user's repositories and contribution count, so the metrics pipeline
+// can run against GitHub, GitLab, Gitea/Forgejo, or any combination of
+// them.
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// Repo is a provider-agnostic view of a single repository, carrying
+// just the fields the metrics pipeline needs regardless of which forge
+// it came from.
+type Repo struct {
+	Name          string
+	Owner         string
+	IsFork        bool
+	IsArchived    bool
+	IsPrivate     bool
+	Topics        []string
+	Stars         int
+	Forks         int
+	UpdatedAt     time.Time
+	DefaultBranch string
+	Languages     map[string]int
+}
+
+// Provider lists repositories and contribution counts for a user on a
+// specific forge.
+type Provider interface {
+	// Name identifies the provider (e.g. "github"), used in log output
+	// and to namespace cached repos across providers.
+	Name() string
+	ListRepos(ctx context.Context, user string) ([]Repo, error)
+	Contributions(ctx context.Context, user string) (int, error)
+}
@@ -0,0 +1,175 @@
+// Package render owns everything SVG-theming related: the set of named
+// themes and layouts a user can select via the THEME/LAYOUT env vars,
+// each theme's color palette, and the template path convention
+// (templates/<theme>/<name>.svg.tmpl) the renderer resolves against.
+package render
+
+import "fmt"
+
+// Theme selects a palette and template set for the rendered SVGs.
+type Theme string
+
+const (
+	ThemeDefault    Theme = "default"
+	ThemeDark       Theme = "dark"
+	ThemeGitHubDark Theme = "github-dark"
+	ThemeDracula    Theme = "dracula"
+	ThemeSolarized  Theme = "solarized"
+)
+
+// ParseTheme maps a THEME env value to a known Theme, defaulting to
+// ThemeDefault for anything empty or unrecognized.
+func ParseTheme(s string) Theme {
+	switch t := Theme(s); t {
+	case ThemeDark, ThemeGitHubDark, ThemeDracula, ThemeSolarized:
+		return t
+	default:
+		return ThemeDefault
+	}
+}
+
+// Layout selects how languages.svg/overview.svg arrange their content.
+// The templates themselves branch on it; it doesn't affect the
+// template path.
+type Layout string
+
+const (
+	LayoutDonut   Layout = "donut"
+	LayoutBar     Layout = "bar"
+	LayoutCompact Layout = "compact"
+)
+
+// ParseLayout maps a LAYOUT env value to a known Layout, defaulting to
+// LayoutDonut for anything empty or unrecognized.
+func ParseLayout(s string) Layout {
+	switch l := Layout(s); l {
+	case LayoutBar, LayoutCompact:
+		return l
+	default:
+		return LayoutDonut
+	}
+}
+
+// AllThemes returns every named theme, in the order templates/ should
+// ship them. Used by callers (and golden-file tests) that need to
+// enumerate the full theme set rather than parse a single env value.
+func AllThemes() []Theme {
+	return []Theme{ThemeDefault, ThemeDark, ThemeGitHubDark, ThemeDracula, ThemeSolarized}
+}
+
+// AllLayouts returns every named layout, in the same spirit as
+// AllThemes.
+func AllLayouts() []Layout {
+	return []Layout{LayoutDonut, LayoutBar, LayoutCompact}
+}
+
+// Meta carries the active theme and layout into template data. It's
+// embedded into TemplateData and OverviewStats so templates can branch
+// on .Theme/.Layout directly, and emit CSS gated by a
+// prefers-color-scheme media query for users who never set THEME.
+type Meta struct {
+	Theme  Theme
+	Layout Layout
+}
+
+// TemplatePath returns the per-theme template path for a template name
+// ("languages" or "overview"), relative to the templates/ directory,
+// e.g. "dark/languages.svg.tmpl".
+func TemplatePath(theme Theme, name string) string {
+	return fmt.Sprintf("%s/%s.svg.tmpl", theme, name)
+}
+
+// Palette is a theme's full color set: chrome colors for the SVG
+// background/text plus a per-language map, so templates never
+// hard-code hex values.
+type Palette struct {
+	Background string
+	Text       string
+	Muted      string
+	Accent     string
+	Languages  map[string]string
+}
+
+// LanguageColor returns the theme's color for lang, falling back to a
+// deterministic cycle (matching the original single-theme renderer)
+// for languages the palette has no dedicated entry for.
+func (p Palette) LanguageColor(lang string, fallbackIndex int) string {
+	if c, ok := p.Languages[lang]; ok {
+		return c
+	}
+	return fallbackCycle[fallbackIndex%len(fallbackCycle)]
+}
+
+var fallbackCycle = []string{
+	"#f1e05a", "#3178c6", "#3e4053", "#e34c26", "#563d7c",
+	"#2b7489", "#427819", "#b07219", "#d62929", "#999999",
+}
+
+// baseLanguages is the original GitHub-style palette, shared by every
+// theme except where a theme overrides specific hues for contrast.
+var baseLanguages = map[string]string{
+	"JavaScript": "#f1e05a", "TypeScript": "#3178c6", "Python": "#3e4053", "Java": "#b07219",
+	"Go": "#00add8", "Rust": "#dea584", "C++": "#f34b7d", "C": "#555555", "C#": "#178600",
+	"PHP": "#4F5D95", "Ruby": "#701516", "Swift": "#ffac45", "Kotlin": "#A97BFF",
+	"Shell": "#89e051", "HTML": "#e34c26", "CSS": "#563d7c", "SCSS": "#c6538c",
+	"Vue": "#2c3e50", "R": "#198ce7", "Scala": "#dc322f", "Haskell": "#5e5086",
+	"Elixir": "#6e4a7e", "Lua": "#000080", "Perl": "#0298c3", "Objective-C": "#438eff",
+	"Assembly": "#6E4C13", "PowerShell": "#012456", "Dart": "#0175C2", "Groovy": "#e69f56",
+	"Dockerfile": "#384d54", "Cuda": "#3A4E3A",
+}
+
+// withOverrides copies base and applies overrides on top, without
+// mutating either input map.
+func withOverrides(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// draculaLanguages and solarizedLanguages tweak the handful of base
+// hues (dark, low-saturation colors like C's gray or Java's brown)
+// that read poorly against those themes' backgrounds.
+var draculaLanguages = withOverrides(baseLanguages, map[string]string{
+	"C": "#6272a4", "Java": "#ffb86c", "Assembly": "#ff79c6",
+})
+
+var solarizedLanguages = withOverrides(baseLanguages, map[string]string{
+	"JavaScript": "#b58900", "C": "#93a1a1", "Vue": "#268bd2",
+})
+
+var palettes = map[Theme]Palette{
+	ThemeDefault: {
+		Background: "#ffffff", Text: "#24292f", Muted: "#57606a", Accent: "#0969da",
+		Languages: baseLanguages,
+	},
+	ThemeDark: {
+		Background: "#0d1117", Text: "#c9d1d9", Muted: "#8b949e", Accent: "#58a6ff",
+		Languages: baseLanguages,
+	},
+	ThemeGitHubDark: {
+		Background: "#0d1117", Text: "#e6edf3", Muted: "#7d8590", Accent: "#2f81f7",
+		Languages: baseLanguages,
+	},
+	ThemeDracula: {
+		Background: "#282a36", Text: "#f8f8f2", Muted: "#6272a4", Accent: "#bd93f9",
+		Languages: draculaLanguages,
+	},
+	ThemeSolarized: {
+		Background: "#fdf6e3", Text: "#657b83", Muted: "#93a1a1", Accent: "#268bd2",
+		Languages: solarizedLanguages,
+	},
+}
+
+// PaletteFor returns theme's Palette, falling back to ThemeDefault's
+// for an unrecognized theme.
+func PaletteFor(theme Theme) Palette {
+	if p, ok := palettes[theme]; ok {
+		return p
+	}
+	return palettes[ThemeDefault]
+}
@@ -0,0 +1,72 @@
+package render
+
+import "testing"
+
+func TestParseTheme(t *testing.T) {
+	cases := map[string]Theme{
+		"":            ThemeDefault,
+		"default":     ThemeDefault,
+		"dark":        ThemeDark,
+		"github-dark": ThemeGitHubDark,
+		"dracula":     ThemeDracula,
+		"solarized":   ThemeSolarized,
+		"nonsense":    ThemeDefault,
+	}
+	for in, want := range cases {
+		if got := ParseTheme(in); got != want {
+			t.Errorf("ParseTheme(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	cases := map[string]Layout{
+		"":         LayoutDonut,
+		"donut":    LayoutDonut,
+		"bar":      LayoutBar,
+		"compact":  LayoutCompact,
+		"nonsense": LayoutDonut,
+	}
+	for in, want := range cases {
+		if got := ParseLayout(in); got != want {
+			t.Errorf("ParseLayout(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTemplatePath(t *testing.T) {
+	if got, want := TemplatePath(ThemeDark, "languages"), "dark/languages.svg.tmpl"; got != want {
+		t.Errorf("TemplatePath(dark, languages) = %q, want %q", got, want)
+	}
+}
+
+func TestPaletteForUnknownFallsBackToDefault(t *testing.T) {
+	if got, want := PaletteFor(Theme("made-up")), PaletteFor(ThemeDefault); got.Background != want.Background {
+		t.Errorf("PaletteFor(unknown).Background = %q, want default %q", got.Background, want.Background)
+	}
+}
+
+func TestLanguageColorFallsBackToCycle(t *testing.T) {
+	p := PaletteFor(ThemeDefault)
+	if _, ok := p.Languages["NotARealLanguage"]; ok {
+		t.Fatal("fixture language unexpectedly has a dedicated palette entry")
+	}
+	got := p.LanguageColor("NotARealLanguage", 0)
+	if got != fallbackCycle[0] {
+		t.Errorf("LanguageColor fallback = %q, want %q", got, fallbackCycle[0])
+	}
+	// index wraps around the cycle instead of panicking
+	got = p.LanguageColor("NotARealLanguage", len(fallbackCycle))
+	if got != fallbackCycle[0] {
+		t.Errorf("LanguageColor fallback at wrap = %q, want %q", got, fallbackCycle[0])
+	}
+}
+
+func TestAllThemesHavePalettes(t *testing.T) {
+	for _, theme := range AllThemes() {
+		p := PaletteFor(theme)
+		if p.Background == "" || p.Text == "" {
+			t.Errorf("theme %q has an incomplete palette: %+v", theme, p)
+		}
+	}
+}
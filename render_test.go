@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iliadmitriev/github-metrics/render"
+)
+
+// fixtureLanguages and fixtureOverview are the inputs every golden
+// fixture under testdata/ was rendered from. Keep them in sync with
+// the Python generator that produced those fixtures if either changes.
+var fixtureLanguages = []LanguageStat{
+	{Name: "Go", Color: "#00add8", Percentage: 0.532},
+	{Name: "Python", Color: "#3e4053", Percentage: 0.318},
+	{Name: "Shell", Color: "#89e051", Percentage: 0.150},
+}
+
+var fixtureOverview = OverviewStats{
+	Name:          "octocat",
+	Stars:         120,
+	Forks:         34,
+	Repos:         18,
+	Contributions: "1,234",
+	LinesChanged:  "45,678",
+	Commits:       210,
+	LongestStreak: 27,
+}
+
+func TestRenderGoldenFiles(t *testing.T) {
+	for _, theme := range render.AllThemes() {
+		for _, layout := range render.AllLayouts() {
+			meta := render.Meta{Theme: theme, Layout: layout}
+
+			t.Run(string(theme)+"/"+string(layout)+"/languages", func(t *testing.T) {
+				data := TemplateData{Meta: meta, Name: fixtureOverview.Name, Languages: fixtureLanguages}
+				assertGolden(t, render.TemplatePath(theme, "languages"), data, string(theme)+"-"+string(layout)+"-languages.svg")
+			})
+
+			t.Run(string(theme)+"/"+string(layout)+"/overview", func(t *testing.T) {
+				data := fixtureOverview
+				data.Meta = meta
+				assertGolden(t, render.TemplatePath(theme, "overview"), data, string(theme)+"-"+string(layout)+"-overview.svg")
+			})
+		}
+	}
+}
+
+func assertGolden(t *testing.T, templatePath string, data interface{}, goldenName string) {
+	t.Helper()
+
+	got, err := renderToBytes(templatePath, data)
+	if err != nil {
+		t.Fatalf("renderToBytes(%q): %v", templatePath, err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", goldenName))
+	if err != nil {
+		t.Fatalf("reading golden file %q: %v", goldenName, err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("rendered output for %s doesn't match testdata/%s\n got: %s\nwant: %s", templatePath, goldenName, got, want)
+	}
+}
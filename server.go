@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/iliadmitriev/github-metrics/render"
+)
+
+// metricsCache holds the most recently collected stats and rendered
+// SVGs so HTTP handlers can serve a scrape instantly instead of
+// blocking on a live provider fetch.
+type metricsCache struct {
+	mu sync.RWMutex
+
+	overview  OverviewStats
+	languages []LanguageStat
+
+	languagesSVG  []byte
+	languagesETag string
+	overviewSVG   []byte
+	overviewETag  string
+
+	lastScrapeOK bool
+	lastScrapeAt time.Time
+	scrapeErrors int
+}
+
+func (m *metricsCache) update(overview OverviewStats, languages []LanguageStat, languagesSVG, overviewSVG []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.overview = overview
+	m.languages = languages
+	m.languagesSVG = languagesSVG
+	m.languagesETag = etag(languagesSVG)
+	m.overviewSVG = overviewSVG
+	m.overviewETag = etag(overviewSVG)
+	m.lastScrapeOK = true
+	m.lastScrapeAt = time.Now()
+}
+
+func (m *metricsCache) recordScrapeError() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastScrapeOK = false
+	m.scrapeErrors++
+}
+
+func (m *metricsCache) snapshot() (overview OverviewStats, languages []LanguageStat, ok bool, errCount int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.overview, m.languages, m.lastScrapeOK, m.scrapeErrors
+}
+
+func etag(b []byte) string {
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:])[:16] + `"`
+}
+
+// runServer starts the --serve exporter: it refreshes stats once
+// synchronously so the first scrape isn't empty, then keeps refreshing
+// on interval in the background while serving /metrics,
+// /languages.svg, /overview.svg and /healthz until SIGINT/SIGTERM.
+func runServer(cfg *Config, addr string, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cache := &metricsCache{}
+	refresh(ctx, cfg, cache)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh(ctx, cfg, cache)
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", handleMetrics(cfg, cache))
+	mux.HandleFunc("/languages.svg", handleSVG(cache, "languages"))
+	mux.HandleFunc("/overview.svg", handleSVG(cache, "overview"))
+	mux.HandleFunc("/healthz", handleHealthz)
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("🚀 Serving metrics on %s (refresh every %s)", addr, interval)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("🛑 Shutting down gracefully...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// refresh runs one scrape and updates cache, recording a scrape error
+// on failure instead of letting a transient provider outage take down
+// the exporter.
+func refresh(ctx context.Context, cfg *Config, cache *metricsCache) {
+	overview, languages, err := collectStats(ctx, cfg)
+	if err != nil {
+		log.Printf("⚠️ Warning: scrape failed: %v", err)
+		cache.recordScrapeError()
+		return
+	}
+
+	languageData := TemplateData{Meta: overview.Meta, Name: cfg.GitHubActor, Languages: languages}
+	languagesSVG, err := renderToBytes(render.TemplatePath(cfg.Theme, "languages"), languageData)
+	if err != nil {
+		log.Printf("⚠️ Warning: Failed to render languages.svg: %v", err)
+		cache.recordScrapeError()
+		return
+	}
+	overviewSVG, err := renderToBytes(render.TemplatePath(cfg.Theme, "overview"), overview)
+	if err != nil {
+		log.Printf("⚠️ Warning: Failed to render overview.svg: %v", err)
+		cache.recordScrapeError()
+		return
+	}
+
+	cache.update(overview, languages, languagesSVG, overviewSVG)
+	log.Printf("✅ Refreshed metrics for %s", cfg.GitHubActor)
+}
+
+func handleMetrics(cfg *Config, cache *metricsCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		overview, languages, ok, errCount := cache.snapshot()
+
+		var b strings.Builder
+		user := strconv.Quote(cfg.GitHubActor)
+
+		writeMetric(&b, "github_stars_total", "gauge", "Total stars across all configured providers.",
+			fmt.Sprintf("{user=%s} %d", user, overview.Stars))
+		writeMetric(&b, "github_forks_total", "gauge", "Total forks across all configured providers.",
+			fmt.Sprintf("{user=%s} %d", user, overview.Forks))
+		writeMetric(&b, "github_repos_total", "gauge", "Total repositories scraped across all configured providers.",
+			fmt.Sprintf("{user=%s} %d", user, overview.Repos))
+		writeMetric(&b, "github_contributions_total", "gauge", "Total contributions across all configured providers.",
+			fmt.Sprintf("{user=%s} %d", user, mustAtoi(strings.ReplaceAll(overview.Contributions, ",", ""))))
+
+		fmt.Fprintf(&b, "# HELP github_language_bytes Bytes of code per language.\n")
+		fmt.Fprintf(&b, "# TYPE github_language_bytes gauge\n")
+		for _, lang := range languages {
+			fmt.Fprintf(&b, "github_language_bytes{user=%s,lang=%s} %d\n", user, strconv.Quote(lang.Name), lang.Size)
+		}
+
+		fmt.Fprintf(&b, "# HELP github_commits_weekly Commit count per calendar week (Monday of that week).\n")
+		fmt.Fprintf(&b, "# TYPE github_commits_weekly gauge\n")
+		for _, week := range overview.WeeklyCommits {
+			fmt.Fprintf(&b, "github_commits_weekly{user=%s,week=%s} %d\n", user, strconv.Quote(week.Week), week.Commits)
+		}
+
+		lastScrapeOK := 0
+		if ok {
+			lastScrapeOK = 1
+		}
+		writeMetric(&b, "github_metrics_last_scrape_success", "gauge", "Whether the last scrape succeeded (1) or failed (0).",
+			fmt.Sprintf(" %d", lastScrapeOK))
+		writeMetric(&b, "github_metrics_scrape_errors_total", "counter", "Number of failed scrapes since the exporter started.",
+			fmt.Sprintf(" %d", errCount))
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write([]byte(b.String()))
+	}
+}
+
+func writeMetric(b *strings.Builder, name, typ, help, sample string) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, typ)
+	fmt.Fprintf(b, "%s%s\n", name, sample)
+}
+
+// mustAtoi parses a formatted stat count back to an int, returning 0
+// for anything unparsable rather than failing a /metrics scrape.
+func mustAtoi(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func handleSVG(cache *metricsCache, which string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cache.mu.RLock()
+		var body []byte
+		var tag string
+		if which == "languages" {
+			body, tag = cache.languagesSVG, cache.languagesETag
+		} else {
+			body, tag = cache.overviewSVG, cache.overviewETag
+		}
+		cache.mu.RUnlock()
+
+		if body == nil {
+			http.Error(w, "stats not yet available", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("ETag", tag)
+		if r.Header.Get("If-None-Match") == tag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write(body)
+	}
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte("ok"))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iliadmitriev/github-metrics/commits"
+)
+
+func TestEtagIsStableAndContentAddressed(t *testing.T) {
+	a := etag([]byte("hello"))
+	b := etag([]byte("hello"))
+	c := etag([]byte("world"))
+
+	if a != b {
+		t.Errorf("etag(%q) changed between calls: %q vs %q", "hello", a, b)
+	}
+	if a == c {
+		t.Errorf("etag(%q) and etag(%q) collided: %q", "hello", "world", a)
+	}
+	if !strings.HasPrefix(a, `"`) || !strings.HasSuffix(a, `"`) {
+		t.Errorf("etag(%q) = %q, want a quoted value", "hello", a)
+	}
+}
+
+func TestMustAtoi(t *testing.T) {
+	cases := map[string]int{
+		"1,234": 0, // mustAtoi doesn't strip commas itself; caller does
+		"1234":  1234,
+		"0":     0,
+		"nope":  0,
+		"":      0,
+	}
+	for in, want := range cases {
+		if got := mustAtoi(in); got != want {
+			t.Errorf("mustAtoi(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestHandleMetricsServesPrometheusText(t *testing.T) {
+	cache := &metricsCache{}
+	cache.update(
+		OverviewStats{
+			Name: "octocat", Stars: 5, Forks: 2, Repos: 3,
+			Contributions: "1,234",
+			WeeklyCommits: []commits.WeekBucket{{Week: "2024-01-01", Commits: 7}},
+		},
+		[]LanguageStat{{Name: "Go", Size: 100}},
+		[]byte("<svg/>"), []byte("<svg/>"),
+	)
+
+	cfg := &Config{GitHubActor: "octocat"}
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	handleMetrics(cfg, cache)(rec, req)
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`github_stars_total{user="octocat"} 5`,
+		`github_forks_total{user="octocat"} 2`,
+		`github_repos_total{user="octocat"} 3`,
+		`github_contributions_total{user="octocat"} 1234`,
+		`github_language_bytes{user="octocat",lang="Go"} 100`,
+		`github_commits_weekly{user="octocat",week="2024-01-01"} 7`,
+		`github_metrics_last_scrape_success`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected /metrics output to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain prefix", ct)
+	}
+}
+
+func TestHandleSVGReturnsNotModifiedOnMatchingETag(t *testing.T) {
+	cache := &metricsCache{}
+	cache.update(OverviewStats{}, nil, []byte("<svg>languages</svg>"), []byte("<svg>overview</svg>"))
+
+	handler := handleSVG(cache, "languages")
+
+	req := httptest.NewRequest("GET", "/languages.svg", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	tag := rec.Header().Get("ETag")
+	if tag == "" {
+		t.Fatal("expected an ETag header on first response")
+	}
+
+	req2 := httptest.NewRequest("GET", "/languages.svg", nil)
+	req2.Header.Set("If-None-Match", tag)
+	rec2 := httptest.NewRecorder()
+	handler(rec2, req2)
+
+	if rec2.Code != 304 {
+		t.Errorf("status = %d, want 304 when If-None-Match matches", rec2.Code)
+	}
+}